@@ -0,0 +1,270 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	gasAlertsFired = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "gas_alerts_fired_total",
+		Help: "The total number of gas alerts that crossed their threshold",
+	})
+	gasAlertsDelivered = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "gas_alerts_delivered_total",
+		Help: "The total number of gas alert deliveries that succeeded",
+	})
+	gasAlertsFailed = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "gas_alerts_failed_total",
+		Help: "The total number of gas alert deliveries that failed after retries",
+	})
+)
+
+// GasAlert represents a threshold that, once crossed, fires a webhook
+type GasAlert struct {
+	ID         int       `json:"id"`
+	Tier       string    `json:"tier"`       // safe, propose, or fast
+	Comparator string    `json:"comparator"` // < or >
+	Gwei       float64   `json:"gwei"`
+	Cooldown   int       `json:"cooldown_seconds" default:"300"`
+	WebhookURL string    `json:"webhook_url"`
+	lastFired  time.Time `json:"-"`
+}
+
+// gasAlertPayload is the JSON body posted to an alert's delivery target
+type gasAlertPayload struct {
+	Chain     string  `json:"chain"`
+	Tier      string  `json:"tier"`
+	Value     float64 `json:"value"`
+	Timestamp int64   `json:"timestamp"`
+}
+
+// tierValue picks the gwei value for the tier an alert is watching
+func tierValue(tiers GasTiers, tier string) (float64, error) {
+	switch tier {
+	case "safe":
+		return tiers.SafeGwei, nil
+	case "propose":
+		return tiers.ProposeGwei, nil
+	case "fast":
+		return tiers.FastGwei, nil
+	default:
+		return 0, fmt.Errorf("unknown gas alert tier: %s", tier)
+	}
+}
+
+// crossed reports whether value crosses the alert's threshold per its comparator
+func (a *GasAlert) crossed(value float64) bool {
+	switch a.Comparator {
+	case "<":
+		return value < a.Gwei
+	case ">":
+		return value > a.Gwei
+	default:
+		return false
+	}
+}
+
+// Evaluate checks every alert against the latest tiers and dispatches the
+// ones that crossed their threshold and are outside their cooldown window
+func (g *Gas) Evaluate(tiers GasTiers, chain string) {
+	for i := range g.Alerts {
+		alert := &g.Alerts[i]
+
+		value, err := tierValue(tiers, alert.Tier)
+		if err != nil {
+			logger.Warningf("Gas alert %d: %s", alert.ID, err)
+			continue
+		}
+
+		if !alert.crossed(value) {
+			continue
+		}
+
+		if time.Since(alert.lastFired) < time.Duration(alert.Cooldown)*time.Second {
+			continue
+		}
+
+		alert.lastFired = time.Now()
+		gasAlertsFired.Inc()
+
+		go dispatchGasAlert(*alert, gasAlertPayload{
+			Chain:     chain,
+			Tier:      alert.Tier,
+			Value:     value,
+			Timestamp: time.Now().Unix(),
+		})
+	}
+}
+
+// dispatchGasAlert posts the alert payload to its delivery target, retrying
+// with exponential backoff before giving up
+func dispatchGasAlert(alert GasAlert, payload gasAlertPayload) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		logger.Errorf("Unable to marshal gas alert payload: %s", err)
+		gasAlertsFailed.Inc()
+		return
+	}
+
+	backoff := time.Second
+	for attempt := 0; attempt < 3; attempt++ {
+		resp, err := http.Post(alert.WebhookURL, "application/json", bytes.NewReader(body))
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode < 300 {
+				gasAlertsDelivered.Inc()
+				return
+			}
+			err = fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		}
+
+		logger.Warningf("Gas alert delivery attempt %d failed: %s", attempt+1, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+
+	gasAlertsFailed.Inc()
+}
+
+// AddGasAlert adds a new alert to an existing watched gas entry
+func (m *Manager) AddGasAlert(w http.ResponseWriter, r *http.Request) {
+	m.Lock()
+	defer m.Unlock()
+
+	logger.Debugf("Got an API request to add a gas alert")
+
+	vars := mux.Vars(r)
+	id := strings.ToLower(vars["id"])
+
+	gas, ok := m.WatchingGas[id]
+	if !ok {
+		logger.Errorf("No gas found: %s", id)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		logger.Errorf("%s", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	var alert GasAlert
+	if err := json.Unmarshal(body, &alert); err != nil {
+		logger.Errorf("Unmarshalling: %s", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if alert.WebhookURL == "" {
+		logger.Error("Webhook URL required")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	switch alert.Tier {
+	case "safe", "propose", "fast":
+	default:
+		logger.Errorf("Unknown gas alert tier: %s", alert.Tier)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	switch alert.Comparator {
+	case "<", ">":
+	default:
+		logger.Errorf("Unknown gas alert comparator: %s", alert.Comparator)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// default to 300s, matching the Cooldown field's tag, since a 0s
+	// cooldown would re-dispatch the webhook on every single poll tick
+	// once the threshold is crossed
+	if alert.Cooldown == 0 {
+		alert.Cooldown = 300
+	}
+
+	gas.Alerts = append(gas.Alerts, alert)
+	m.saveGasAlerts(id, gas)
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(gas.Alerts); err != nil {
+		logger.Errorf("Unable to encode gas alerts: %s", err)
+	}
+}
+
+// DeleteGasAlert removes an alert from an existing watched gas entry
+func (m *Manager) DeleteGasAlert(w http.ResponseWriter, r *http.Request) {
+	m.Lock()
+	defer m.Unlock()
+
+	logger.Debugf("Got an API request to delete a gas alert")
+
+	vars := mux.Vars(r)
+	id := strings.ToLower(vars["id"])
+
+	gas, ok := m.WatchingGas[id]
+	if !ok {
+		logger.Errorf("No gas found: %s", id)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	alertID, err := strconv.Atoi(vars["alertId"])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	kept := gas.Alerts[:0]
+	for _, alert := range gas.Alerts {
+		if alert.ID != alertID {
+			kept = append(kept, alert)
+		}
+	}
+	gas.Alerts = kept
+	m.saveGasAlerts(id, gas)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListGasAlerts returns the alerts configured on a watched gas entry
+func (m *Manager) ListGasAlerts(w http.ResponseWriter, r *http.Request) {
+	m.RLock()
+	defer m.RUnlock()
+
+	vars := mux.Vars(r)
+	id := strings.ToLower(vars["id"])
+
+	gas, ok := m.WatchingGas[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(gas.Alerts); err != nil {
+		logger.Errorf("Serving request: %s", err)
+	}
+}
+
+// saveGasAlerts persists a gas entry's alerts in the gas_alerts table, joined
+// to its row in gases
+func (m *Manager) saveGasAlerts(id string, gas *Gas) {
+	m.persistGas(id, gas)
+}
@@ -0,0 +1,59 @@
+package main
+
+import "testing"
+
+func TestTierValue(t *testing.T) {
+	tiers := GasTiers{SafeGwei: 10, ProposeGwei: 15, FastGwei: 20}
+
+	cases := []struct {
+		tier    string
+		want    float64
+		wantErr bool
+	}{
+		{tier: "safe", want: 10},
+		{tier: "propose", want: 15},
+		{tier: "fast", want: 20},
+		{tier: "bogus", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		got, err := tierValue(tiers, tc.tier)
+		if tc.wantErr {
+			if err == nil {
+				t.Fatalf("tierValue(%q) = %v, want error", tc.tier, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("tierValue(%q) returned unexpected error: %s", tc.tier, err)
+		}
+		if got != tc.want {
+			t.Fatalf("tierValue(%q) = %v, want %v", tc.tier, got, tc.want)
+		}
+	}
+}
+
+func TestGasAlertCrossed(t *testing.T) {
+	cases := []struct {
+		name       string
+		comparator string
+		threshold  float64
+		value      float64
+		want       bool
+	}{
+		{name: "less than, below threshold", comparator: "<", threshold: 10, value: 5, want: true},
+		{name: "less than, at threshold", comparator: "<", threshold: 10, value: 10, want: false},
+		{name: "greater than, above threshold", comparator: ">", threshold: 10, value: 15, want: true},
+		{name: "greater than, at threshold", comparator: ">", threshold: 10, value: 10, want: false},
+		{name: "unknown comparator", comparator: "!=", threshold: 10, value: 999, want: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			alert := GasAlert{Comparator: tc.comparator, Gwei: tc.threshold}
+			if got := alert.crossed(tc.value); got != tc.want {
+				t.Fatalf("crossed(%v) = %v, want %v", tc.value, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GasConfigDocument is the bulk import/export shape for every watched gas
+// ticker, so deployments can be driven from a single checked-in file
+type GasConfigDocument struct {
+	Prune bool             `json:"prune" yaml:"prune"`
+	Gases []GasConfigEntry `json:"gases" yaml:"gases"`
+}
+
+// GasConfigEntry mirrors GasRequest, minus the one-off validation concerns
+// that only matter for a single HTTP request
+type GasConfigEntry struct {
+	Networks    []string   `json:"networks" yaml:"networks"`
+	Token       string     `json:"discord_bot_token" yaml:"discord_bot_token"`
+	Nickname    bool       `json:"set_nickname" yaml:"set_nickname"`
+	Frequency   int        `json:"frequency" yaml:"frequency"`
+	Provider    string     `json:"provider" yaml:"provider"`
+	ApiKey      string     `json:"api_key" yaml:"api_key"`
+	DisplayMode string     `json:"display_mode" yaml:"display_mode"`
+	Alerts      []GasAlert `json:"alerts" yaml:"alerts"`
+}
+
+// isYAML decides the document format from the request's Content-Type
+func isYAML(contentType string) bool {
+	return strings.Contains(contentType, "yaml") || strings.Contains(contentType, "yml")
+}
+
+// ImportGas bulk-creates or updates every gas ticker described in the
+// posted document. Existing entries (matched by token + network set) are
+// updated in place, missing ones are created, and when prune is set any
+// watched entry absent from the document is deleted
+func (m *Manager) ImportGas(w http.ResponseWriter, r *http.Request) {
+	m.Lock()
+	defer m.Unlock()
+
+	logger.Debugf("Got an API request to import gas configs")
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		logger.Errorf("%s", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	var doc GasConfigDocument
+	if isYAML(r.Header.Get("Content-Type")) {
+		err = yaml.Unmarshal(body, &doc)
+	} else {
+		err = json.Unmarshal(body, &doc)
+	}
+	if err != nil {
+		logger.Errorf("Unmarshalling gas import: %s", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	seen := make(map[string]bool, len(doc.Gases))
+
+	for _, entry := range doc.Gases {
+		if entry.Token == "" || len(entry.Networks) == 0 {
+			logger.Warningf("Skipping gas import entry missing token or networks")
+			continue
+		}
+
+		if entry.Provider == "" {
+			entry.Provider = "etherscan"
+		}
+		if entry.DisplayMode == "" {
+			entry.DisplayMode = "fast_nickname"
+		}
+
+		// ensure the provider is one we know how to talk to, same as AddGas
+		if _, err := NewGasSource(entry.Provider, entry.ApiKey, entry.Networks[0]); err != nil {
+			logger.Warningf("Skipping gas import entry with unknown provider %s: %s", entry.Provider, err)
+			continue
+		}
+
+		groupKey := gasGroupKey(entry.Token, entry.Networks)
+		seen[groupKey] = true
+
+		if existing, ok := m.WatchingGas[groupKey]; ok {
+			existing.Nickname = entry.Nickname
+			existing.Frequency = entry.Frequency
+			existing.Provider = entry.Provider
+			existing.ApiKey = entry.ApiKey
+			existing.DisplayMode = entry.DisplayMode
+			existing.Alerts = entry.Alerts
+			// already counted and watched - only re-persist, don't re-Inc gasCount
+			m.persistGas(groupKey, existing)
+			continue
+		}
+
+		gas := NewGas(entry.Networks, entry.Token, entry.Nickname, entry.Frequency, entry.Provider, entry.ApiKey, entry.DisplayMode, entry.Alerts)
+		m.addGas(gas)
+	}
+
+	if doc.Prune {
+		for groupKey, gas := range m.WatchingGas {
+			if seen[groupKey] {
+				continue
+			}
+			gas.Shutdown()
+			forgetPollRotation(gas)
+			gasCount.Dec()
+			if m.Store != nil {
+				if err := m.Store.Delete(context.Background(), gas.token, gas.Networks); err != nil {
+					logger.Warningf("Unable to delete pruned gas from db %s: %s", groupKey, err)
+				}
+			}
+			delete(m.WatchingGas, groupKey)
+			logger.Infof("Pruned gas %s", groupKey)
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// ExportGas writes every watched gas ticker as a single document, in JSON
+// by default or YAML when requested via Accept or ?format=yaml
+func (m *Manager) ExportGas(w http.ResponseWriter, r *http.Request) {
+	m.RLock()
+	defer m.RUnlock()
+
+	logger.Debugf("Got an API request to export gas configs")
+
+	doc := GasConfigDocument{}
+	for _, gas := range m.WatchingGas {
+		doc.Gases = append(doc.Gases, GasConfigEntry{
+			Networks:    gas.Networks,
+			Token:       gas.token,
+			Nickname:    gas.Nickname,
+			Frequency:   gas.Frequency,
+			Provider:    gas.Provider,
+			ApiKey:      gas.ApiKey,
+			DisplayMode: gas.DisplayMode,
+			Alerts:      gas.Alerts,
+		})
+	}
+
+	wantYAML := isYAML(r.Header.Get("Accept")) || r.URL.Query().Get("format") == "yaml"
+	if wantYAML {
+		w.Header().Set("Content-Type", "application/yaml; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if err := yaml.NewEncoder(w).Encode(doc); err != nil {
+			logger.Errorf("Encoding gas export: %s", err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(doc); err != nil {
+		logger.Errorf("Encoding gas export: %s", err)
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/DrMaxis/discord-stock-ticker/storage"
+)
+
+// Shutdown stops serving HTTP, cancels every watched gas entry, and waits for
+// them to finish before flushing the store and returning. It is meant to be
+// called once, from a signal handler in main, so a restart never drops
+// in-flight work
+func (m *Manager) Shutdown(ctx context.Context) error {
+	// the HTTP server is drained before taking the manager lock, since
+	// in-flight handlers need that lock to finish
+	if m.Server != nil {
+		if err := m.Server.Shutdown(ctx); err != nil {
+			logger.Errorf("Shutting down HTTP server: %s", err)
+		}
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	var wg sync.WaitGroup
+	for _, gas := range m.WatchingGas {
+		wg.Add(1)
+		go func(gas *Gas) {
+			defer wg.Done()
+			gas.Shutdown()
+			forgetPollRotation(gas)
+		}(gas)
+	}
+	wg.Wait()
+
+	if m.Store != nil {
+		if err := m.Store.Close(); err != nil {
+			logger.Errorf("Closing gas store: %s", err)
+			return err
+		}
+	}
+
+	logger.Info("Manager shutdown complete")
+	return nil
+}
+
+// ReloadGas re-reads every persisted gas row and reconciles it against the
+// running goroutines: new rows are started, removed rows are stopped, and
+// changed rows are restarted with their new settings. Nothing in the process
+// is dropped for rows that are unchanged
+func (m *Manager) ReloadGas(w http.ResponseWriter, r *http.Request) {
+	m.Lock()
+	defer m.Unlock()
+
+	logger.Debugf("Got an API request to reload gas from persisted state")
+
+	if m.Store == nil {
+		w.WriteHeader(http.StatusNotImplemented)
+		return
+	}
+
+	records, err := m.Store.List(context.Background())
+	if err != nil {
+		logger.Errorf("Listing persisted gas for reload: %s", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	desired := make(map[string]storage.GasRecord, len(records))
+	for _, rec := range records {
+		desired[gasGroupKey(rec.Token, rec.Networks)] = rec
+	}
+
+	// stop and remove anything no longer persisted
+	for groupKey, gas := range m.WatchingGas {
+		if _, ok := desired[groupKey]; ok {
+			continue
+		}
+		gas.Shutdown()
+		forgetPollRotation(gas)
+		gasCount.Dec()
+		delete(m.WatchingGas, groupKey)
+		logger.Infof("Reload stopped gas %s", groupKey)
+	}
+
+	// start new entries and restart changed ones
+	for groupKey, rec := range desired {
+		existing, ok := m.WatchingGas[groupKey]
+		if ok && !gasChanged(existing, rec) {
+			continue
+		}
+
+		if ok {
+			existing.Shutdown()
+			forgetPollRotation(existing)
+			gasCount.Dec()
+			delete(m.WatchingGas, groupKey)
+		}
+
+		alerts := make([]GasAlert, 0, len(rec.Alerts))
+		for _, a := range rec.Alerts {
+			alerts = append(alerts, GasAlert{
+				ID:         a.ID,
+				Tier:       a.Tier,
+				Comparator: a.Comparator,
+				Gwei:       a.Gwei,
+				Cooldown:   a.CooldownSeconds,
+				WebhookURL: a.WebhookURL,
+			})
+		}
+
+		gas := NewGas(rec.Networks, rec.Token, rec.Nickname, rec.Frequency, rec.Provider, rec.ApiKey, rec.DisplayMode, alerts)
+		gasCount.Inc()
+		m.WatchingGas[groupKey] = gas
+		logger.Infof("Reload started gas %s", groupKey)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// gasChanged reports whether a persisted record's settings drifted from the
+// currently running gas entry
+func gasChanged(gas *Gas, rec storage.GasRecord) bool {
+	if gas.Nickname != rec.Nickname ||
+		gas.Frequency != rec.Frequency ||
+		gas.Provider != rec.Provider ||
+		gas.ApiKey != rec.ApiKey ||
+		gas.DisplayMode != rec.DisplayMode {
+		return true
+	}
+	return alertsChanged(gas.Alerts, rec.Alerts)
+}
+
+// alertsChanged reports whether a gas entry's in-memory alerts differ from
+// its persisted record, matched by ID so an edit to an existing alert's
+// fields is caught even when the alert count stays the same
+func alertsChanged(alerts []GasAlert, recAlerts []storage.GasAlert) bool {
+	if len(alerts) != len(recAlerts) {
+		return true
+	}
+
+	byID := make(map[int]storage.GasAlert, len(recAlerts))
+	for _, a := range recAlerts {
+		byID[a.ID] = a
+	}
+
+	for _, a := range alerts {
+		rec, ok := byID[a.ID]
+		if !ok {
+			return true
+		}
+		if rec.Tier != a.Tier ||
+			rec.Comparator != a.Comparator ||
+			rec.Gwei != a.Gwei ||
+			rec.CooldownSeconds != a.Cooldown ||
+			rec.WebhookURL != a.WebhookURL {
+			return true
+		}
+	}
+	return false
+}
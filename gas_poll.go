@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// pollRotations tracks, per watched gas entry, which network is due to be
+// fetched next. Gas is defined outside this package's file set so the index
+// can't live on the struct itself; keying off the pointer here keeps each
+// entry's cadence independent of its siblings without needing a new field.
+var pollRotations = struct {
+	sync.Mutex
+	next map[*Gas]int
+}{next: make(map[*Gas]int)}
+
+// nextPollNetwork returns the network due for this tick and advances the
+// entry's rotation, so a group of chains is cycled through one at a time
+// rather than all fetched (and rate-limited) on every tick
+func nextPollNetwork(g *Gas) string {
+	pollRotations.Lock()
+	defer pollRotations.Unlock()
+
+	idx := pollRotations.next[g] % len(g.Networks)
+	pollRotations.next[g] = (idx + 1) % len(g.Networks)
+	return g.Networks[idx]
+}
+
+// forgetPollRotation drops a gas entry's rotation state. Callers must invoke
+// this alongside gas.Shutdown() so pollRotations doesn't grow unbounded as
+// entries are deleted, pruned, or reloaded away
+func forgetPollRotation(g *Gas) {
+	pollRotations.Lock()
+	defer pollRotations.Unlock()
+	delete(pollRotations.next, g)
+}
+
+// Poll fetches the latest tiers for the next network due in the entry's
+// rotation, applies DisplayMode to pick the nickname/activity strings, and
+// evaluates alerts against the result. It is the single tick that the
+// (per-gas, frequency-driven) polling goroutine is expected to call; a gas
+// entry watching several networks gets one network's worth of work per
+// tick, so each chain is polled - and rate-limited - independently of the
+// others instead of all at once.
+func (g *Gas) Poll(ctx context.Context) (GasTiers, string, string, error) {
+	if len(g.Networks) == 0 {
+		return GasTiers{}, "", "", fmt.Errorf("gas entry has no networks configured")
+	}
+
+	network := nextPollNetwork(g)
+
+	source, err := NewGasSource(g.Provider, g.ApiKey, network)
+	if err != nil {
+		return GasTiers{}, "", "", fmt.Errorf("building gas source for %s: %w", network, err)
+	}
+
+	tiers, err := source.Fetch(ctx)
+	if err != nil {
+		return GasTiers{}, "", "", fmt.Errorf("fetching gas tiers for %s: %w", network, err)
+	}
+
+	nickname, activity := g.displayStrings(network, tiers)
+	g.Evaluate(tiers, network)
+
+	return tiers, nickname, activity, nil
+}
+
+// displayStrings renders the nickname/activity pair for the current
+// DisplayMode, labelling whichever network was just polled so a rotating
+// multi-chain entry's nickname shows which chain it's currently displaying
+func (g *Gas) displayStrings(network string, tiers GasTiers) (nickname, activity string) {
+	fastLine := fmt.Sprintf("%s: %.0f gwei", network, tiers.FastGwei)
+	baseTipLine := fmt.Sprintf("%s: base %.0f / tip %.0f gwei", network, tiers.BaseFeeGwei, tiers.PriorityFeePercentile)
+
+	switch g.DisplayMode {
+	case "base_tip_nickname":
+		return baseTipLine, fastLine
+	default: // "fast_nickname"
+		return fastLine, baseTipLine
+	}
+}
@@ -1,21 +1,48 @@
 package main
 
 import (
-	"database/sql"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"sort"
 	"strings"
 
+	"github.com/DrMaxis/discord-stock-ticker/storage"
 	"github.com/gorilla/mux"
 )
 
 // GasRequest represents the json coming in from the request
 type GasRequest struct {
-	Network   string `json:"network"`
-	Token     string `json:"discord_bot_token"`
-	Nickname  bool   `json:"set_nickname"`
-	Frequency int    `json:"frequency" default:"60"`
+	Network     string     `json:"network"`
+	Networks    []string   `json:"networks"`
+	Token       string     `json:"discord_bot_token"`
+	Nickname    bool       `json:"set_nickname"`
+	Frequency   int        `json:"frequency" default:"60"`
+	Provider    string     `json:"provider" default:"etherscan"`
+	ApiKey      string     `json:"api_key"`
+	DisplayMode string     `json:"display_mode" default:"fast_nickname"`
+	Alerts      []GasAlert `json:"alerts"`
+}
+
+// gasGroupKey builds the WatchingGas map key for a bot token watching a set
+// of networks, so one bot can aggregate several chains instead of needing
+// one bot per network. It is an opaque hash rather than the token itself,
+// since this key is also used as the path parameter for delete/alert routes
+// and as the JSON key in GetGas's response - neither of which should ever
+// leak the Discord bot token.
+func gasGroupKey(token string, networks []string) string {
+	sorted := make([]string, len(networks))
+	copy(sorted, networks)
+	for i := range sorted {
+		sorted[i] = strings.ToUpper(sorted[i])
+	}
+	sort.Strings(sorted)
+
+	sum := sha256.Sum256([]byte(strings.ToUpper(token) + ":" + strings.Join(sorted, ",")))
+	return hex.EncodeToString(sum[:])
 }
 
 // AddTicker adds a new Ticker or crypto to the list of what to watch
@@ -48,21 +75,43 @@ func (m *Manager) AddGas(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// ensure network is set
-	if gasReq.Network == "" {
+	// fall back to the single network field for backwards compatibility
+	if len(gasReq.Networks) == 0 && gasReq.Network != "" {
+		gasReq.Networks = []string{gasReq.Network}
+	}
+
+	// ensure at least one network is set
+	if len(gasReq.Networks) == 0 {
 		logger.Error("Network required")
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
 	// check if already existing
-	if _, ok := m.WatchingGas[strings.ToUpper(gasReq.Network)]; ok {
+	groupKey := gasGroupKey(gasReq.Token, gasReq.Networks)
+	if _, ok := m.WatchingGas[groupKey]; ok {
 		logger.Error("Network already exists")
 		w.WriteHeader(http.StatusConflict)
 		return
 	}
 
-	gas := NewGas(gasReq.Network, gasReq.Token, gasReq.Nickname, gasReq.Frequency)
+	// default to etherscan when no provider is given, preserving old behavior
+	if gasReq.Provider == "" {
+		gasReq.Provider = "etherscan"
+	}
+
+	// ensure the provider is one we know how to talk to
+	if _, err := NewGasSource(gasReq.Provider, gasReq.ApiKey, gasReq.Networks[0]); err != nil {
+		logger.Errorf("Unknown gas provider %s: %s", gasReq.Provider, err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if gasReq.DisplayMode == "" {
+		gasReq.DisplayMode = "fast_nickname"
+	}
+
+	gas := NewGas(gasReq.Networks, gasReq.Token, gasReq.Nickname, gasReq.Frequency, gasReq.Provider, gasReq.ApiKey, gasReq.DisplayMode, gasReq.Alerts)
 	m.addGas(gas)
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
@@ -74,82 +123,77 @@ func (m *Manager) AddGas(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// addGas registers a brand new gas entry: it counts it, adds it to the
+// watch map, and persists it. Callers updating an entry that's already
+// being watched must use persistGas instead, or gasCount drifts upward on
+// every re-application of an unchanged config.
 func (m *Manager) addGas(gas *Gas) {
 	gasCount.Inc()
-	id := gas.Network
+	id := gasGroupKey(gas.token, gas.Networks)
 	m.WatchingGas[id] = gas
+	m.persistGas(id, gas)
+}
 
-	var noDB *sql.DB
-	if m.DB == noDB {
-		return
-	}
-
-	// query
-	stmt, err := m.DB.Prepare("SELECT id FROM gases WHERE network = ? LIMIT 1")
-	if err != nil {
-		logger.Warningf("Unable to query gas in db %s: %s", id, err)
+// persistGas writes a gas entry's current settings and alerts to the store
+// without touching the watch map or gasCount. gas.Alerts is replaced with
+// the store's copy, carrying its auto-increment IDs, so in-memory alerts
+// match what a /reload would read back from the database; the replacement
+// alerts start with no cooldown history.
+func (m *Manager) persistGas(id string, gas *Gas) {
+	if m.Store == nil {
 		return
 	}
 
-	rows, err := stmt.Query(gas.Network)
+	rec, err := m.Store.Upsert(context.Background(), gasToRecord(gas))
 	if err != nil {
-		logger.Warningf("Unable to query gas in db %s: %s", id, err)
+		logger.Warningf("Unable to store gas in db %s: %s", id, err)
 		return
 	}
 
-	var existingId int
+	gas.Alerts = recordAlertsToGasAlerts(rec.Alerts)
+	logger.Infof("Persisted gas %s", id)
+}
 
-	for rows.Next() {
-		err = rows.Scan(&existingId)
-		if err != nil {
-			logger.Warningf("Unable to query gas in db %s: %s", id, err)
-			return
-		}
+// recordAlertsToGasAlerts converts persisted alert records, with their real
+// database IDs, back into the in-memory GasAlert form
+func recordAlertsToGasAlerts(alerts []storage.GasAlert) []GasAlert {
+	out := make([]GasAlert, 0, len(alerts))
+	for _, alert := range alerts {
+		out = append(out, GasAlert{
+			ID:         alert.ID,
+			Tier:       alert.Tier,
+			Comparator: alert.Comparator,
+			Gwei:       alert.Gwei,
+			Cooldown:   alert.CooldownSeconds,
+			WebhookURL: alert.WebhookURL,
+		})
 	}
-	rows.Close()
-
-	if existingId != 0 {
-
-		// update entry in db
-		stmt, err := m.DB.Prepare("update gases set token = ?, nickname = ?, network = ?, frequency = ? WHERE id = ?")
-		if err != nil {
-			logger.Warningf("Unable to update gas in db %s: %s", id, err)
-			return
-		}
-
-		res, err := stmt.Exec(gas.token, gas.Nickname, gas.Network, gas.Frequency, existingId)
-		if err != nil {
-			logger.Warningf("Unable to update gas in db %s: %s", id, err)
-			return
-		}
-
-		_, err = res.LastInsertId()
-		if err != nil {
-			logger.Warningf("Unable to update gas in db %s: %s", id, err)
-			return
-		}
-
-		logger.Infof("Updated gas in db %s", id)
-	} else {
-
-		// store new entry in db
-		stmt, err := m.DB.Prepare("INSERT INTO gases(token, nickname, network, frequency) values(?,?,?,?)")
-		if err != nil {
-			logger.Warningf("Unable to store gas in db %s: %s", id, err)
-			return
-		}
+	return out
+}
 
-		res, err := stmt.Exec(gas.token, gas.Nickname, gas.Network, gas.Frequency)
-		if err != nil {
-			logger.Warningf("Unable to store gas in db %s: %s", id, err)
-			return
-		}
+// gasToRecord converts the in-memory Gas into its persisted form
+func gasToRecord(gas *Gas) storage.GasRecord {
+	alerts := make([]storage.GasAlert, 0, len(gas.Alerts))
+	for _, alert := range gas.Alerts {
+		alerts = append(alerts, storage.GasAlert{
+			ID:              alert.ID,
+			Tier:            alert.Tier,
+			Comparator:      alert.Comparator,
+			Gwei:            alert.Gwei,
+			CooldownSeconds: alert.Cooldown,
+			WebhookURL:      alert.WebhookURL,
+		})
+	}
 
-		_, err = res.LastInsertId()
-		if err != nil {
-			logger.Warningf("Unable to store gas in db %s: %s", id, err)
-			return
-		}
+	return storage.GasRecord{
+		Token:       gas.token,
+		Nickname:    gas.Nickname,
+		Networks:    gas.Networks,
+		Frequency:   gas.Frequency,
+		Provider:    gas.Provider,
+		ApiKey:      gas.ApiKey,
+		DisplayMode: gas.DisplayMode,
+		Alerts:      alerts,
 	}
 }
 
@@ -161,17 +205,26 @@ func (m *Manager) DeleteGas(w http.ResponseWriter, r *http.Request) {
 	logger.Debugf("Got an API request to delete a gas")
 
 	vars := mux.Vars(r)
-	id := strings.ToUpper(vars["id"])
+	id := strings.ToLower(vars["id"])
 
-	if _, ok := m.WatchingGas[id]; !ok {
+	gas, ok := m.WatchingGas[id]
+	if !ok {
 		logger.Errorf("No gas found: %s", id)
 		w.WriteHeader(http.StatusNotFound)
 		return
 	}
+
 	// send shutdown sign
-	m.WatchingGas[id].Shutdown()
+	gas.Shutdown()
+	forgetPollRotation(gas)
 	gasCount.Dec()
 
+	if m.Store != nil {
+		if err := m.Store.Delete(context.Background(), gas.token, gas.Networks); err != nil {
+			logger.Warningf("Unable to delete gas from db %s: %s", id, err)
+		}
+	}
+
 	// remove from cache
 	delete(m.WatchingGas, id)
 
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"github.com/gorilla/mux"
+)
+
+// registerGasRoutes wires every gas HTTP handler added across this feature
+// series onto router, so they're reachable instead of merely defined as
+// Manager methods
+func registerGasRoutes(router *mux.Router, m *Manager) {
+	router.HandleFunc("/gas", m.AddGas).Methods("POST")
+	router.HandleFunc("/gas", m.GetGas).Methods("GET")
+	router.HandleFunc("/gas/{id}", m.DeleteGas).Methods("DELETE")
+
+	router.HandleFunc("/gas/{id}/alerts", m.ListGasAlerts).Methods("GET")
+	router.HandleFunc("/gas/{id}/alerts", m.AddGasAlert).Methods("POST")
+	router.HandleFunc("/gas/{id}/alerts/{alertId}", m.DeleteGasAlert).Methods("DELETE")
+
+	router.HandleFunc("/gas/import", m.ImportGas).Methods("POST")
+	router.HandleFunc("/gas/export", m.ExportGas).Methods("GET")
+
+	router.HandleFunc("/reload", m.ReloadGas).Methods("POST")
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// GasTiers represents the EIP-1559 fee tiers returned by a gas data source
+type GasTiers struct {
+	SafeGwei              float64 `json:"safe_gwei"`
+	ProposeGwei           float64 `json:"propose_gwei"`
+	FastGwei              float64 `json:"fast_gwei"`
+	BaseFeeGwei           float64 `json:"base_fee_gwei"`
+	PriorityFeePercentile float64 `json:"priority_fee_percentile_gwei"`
+}
+
+// GasSource fetches the current gas tiers from a provider
+type GasSource interface {
+	Fetch(ctx context.Context) (GasTiers, error)
+}
+
+// gasSourceClient is shared across adapters so fetches share sane timeouts
+var gasSourceClient = &http.Client{Timeout: 10 * time.Second}
+
+// NewGasSource builds the GasSource for the requested provider
+func NewGasSource(provider, apiKey, network string) (GasSource, error) {
+	switch provider {
+	case "etherscan", "":
+		return &EtherscanSource{apiKey: apiKey}, nil
+	case "blocknative":
+		return &BlocknativeSource{apiKey: apiKey}, nil
+	case "owlracle":
+		return &OwlracleSource{apiKey: apiKey, network: network}, nil
+	case "rpc":
+		return &RPCFeeHistorySource{endpoint: apiKey}, nil
+	default:
+		return nil, fmt.Errorf("unsupported gas provider: %s", provider)
+	}
+}
+
+// EtherscanSource fetches gas tiers from the Etherscan gas oracle API
+type EtherscanSource struct {
+	apiKey string
+}
+
+// Fetch queries the Etherscan gas oracle endpoint
+func (s *EtherscanSource) Fetch(ctx context.Context) (GasTiers, error) {
+	url := fmt.Sprintf("https://api.etherscan.io/api?module=gastracker&action=gasoracle&apikey=%s", s.apiKey)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return GasTiers{}, fmt.Errorf("etherscan: building request: %w", err)
+	}
+
+	resp, err := gasSourceClient.Do(req)
+	if err != nil {
+		return GasTiers{}, fmt.Errorf("etherscan: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var out etherscanResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return GasTiers{}, fmt.Errorf("etherscan: decoding response: %w", err)
+	}
+	return etherscanTiers(out)
+}
+
+// etherscanResponse is the decoded shape of an Etherscan gas oracle response
+type etherscanResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Result  struct {
+		SafeGasPrice    string `json:"SafeGasPrice"`
+		ProposeGasPrice string `json:"ProposeGasPrice"`
+		FastGasPrice    string `json:"FastGasPrice"`
+		SuggestBaseFee  string `json:"suggestBaseFee"`
+	} `json:"result"`
+}
+
+// etherscanTiers converts a decoded Etherscan response into GasTiers
+func etherscanTiers(out etherscanResponse) (GasTiers, error) {
+	if out.Status != "1" {
+		return GasTiers{}, fmt.Errorf("etherscan: %s", out.Message)
+	}
+
+	safe, _ := parseGwei(out.Result.SafeGasPrice)
+	propose, _ := parseGwei(out.Result.ProposeGasPrice)
+	fast, _ := parseGwei(out.Result.FastGasPrice)
+	base, _ := parseGwei(out.Result.SuggestBaseFee)
+
+	return GasTiers{
+		SafeGwei:    safe,
+		ProposeGwei: propose,
+		FastGwei:    fast,
+		BaseFeeGwei: base,
+	}, nil
+}
+
+// BlocknativeSource fetches gas tiers from the Blocknative Gas Platform API
+type BlocknativeSource struct {
+	apiKey string
+}
+
+// Fetch queries the Blocknative gas prediction endpoint
+func (s *BlocknativeSource) Fetch(ctx context.Context) (GasTiers, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.blocknative.com/gasprices/blockprices", nil)
+	if err != nil {
+		return GasTiers{}, fmt.Errorf("blocknative: building request: %w", err)
+	}
+	req.Header.Set("Authorization", s.apiKey)
+
+	resp, err := gasSourceClient.Do(req)
+	if err != nil {
+		return GasTiers{}, fmt.Errorf("blocknative: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var out blocknativeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return GasTiers{}, fmt.Errorf("blocknative: decoding response: %w", err)
+	}
+	return blocknativeTiers(out)
+}
+
+// blocknativeResponse is the decoded shape of a Blocknative block-prices response
+type blocknativeResponse struct {
+	BlockPrices []struct {
+		BaseFeePerGas   float64 `json:"baseFeePerGas"`
+		EstimatedPrices []struct {
+			Confidence           int     `json:"confidence"`
+			Price                float64 `json:"price"`
+			MaxPriorityFeePerGas float64 `json:"maxPriorityFeePerGas"`
+		} `json:"estimatedPrices"`
+	} `json:"blockPrices"`
+}
+
+// blocknativeTiers converts a decoded Blocknative response into GasTiers
+func blocknativeTiers(out blocknativeResponse) (GasTiers, error) {
+	if len(out.BlockPrices) == 0 {
+		return GasTiers{}, fmt.Errorf("blocknative: no block price estimates returned")
+	}
+
+	tiers := GasTiers{BaseFeeGwei: out.BlockPrices[0].BaseFeePerGas}
+	for _, estimate := range out.BlockPrices[0].EstimatedPrices {
+		switch estimate.Confidence {
+		case 70:
+			tiers.SafeGwei = estimate.Price
+		case 90:
+			tiers.ProposeGwei = estimate.Price
+		case 99:
+			tiers.FastGwei = estimate.Price
+			tiers.PriorityFeePercentile = estimate.MaxPriorityFeePerGas
+		}
+	}
+
+	return tiers, nil
+}
+
+// OwlracleSource fetches gas tiers from the Owlracle API
+type OwlracleSource struct {
+	apiKey  string
+	network string
+}
+
+// Fetch queries the Owlracle gas endpoint
+func (s *OwlracleSource) Fetch(ctx context.Context) (GasTiers, error) {
+	url := fmt.Sprintf("https://api.owlracle.info/v4/%s/gas?apikey=%s", s.network, s.apiKey)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return GasTiers{}, fmt.Errorf("owlracle: building request: %w", err)
+	}
+
+	resp, err := gasSourceClient.Do(req)
+	if err != nil {
+		return GasTiers{}, fmt.Errorf("owlracle: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var out owlracleResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return GasTiers{}, fmt.Errorf("owlracle: decoding response: %w", err)
+	}
+	return owlracleTiers(out)
+}
+
+// owlracleResponse is the decoded shape of an Owlracle gas response
+type owlracleResponse struct {
+	BaseFee float64 `json:"baseFee"`
+	Speeds  []struct {
+		Acceptance           float64 `json:"acceptance"`
+		MaxFeePerGas         float64 `json:"maxFeePerGas"`
+		MaxPriorityFeePerGas float64 `json:"maxPriorityFeePerGas"`
+	} `json:"speeds"`
+}
+
+// owlracleTiers converts a decoded Owlracle response into GasTiers
+func owlracleTiers(out owlracleResponse) (GasTiers, error) {
+	if len(out.Speeds) == 0 {
+		return GasTiers{}, fmt.Errorf("owlracle: no speeds returned")
+	}
+
+	tiers := GasTiers{BaseFeeGwei: out.BaseFee}
+	for _, speed := range out.Speeds {
+		switch {
+		case speed.Acceptance <= 0.35:
+			tiers.SafeGwei = speed.MaxFeePerGas
+		case speed.Acceptance <= 0.6:
+			tiers.ProposeGwei = speed.MaxFeePerGas
+		default:
+			tiers.FastGwei = speed.MaxFeePerGas
+			tiers.PriorityFeePercentile = speed.MaxPriorityFeePerGas
+		}
+	}
+
+	return tiers, nil
+}
+
+// RPCFeeHistorySource fetches gas tiers from a self-hosted JSON-RPC node using eth_feeHistory
+type RPCFeeHistorySource struct {
+	endpoint string
+}
+
+// Fetch calls eth_feeHistory on the configured node and derives safe/propose/fast tiers
+// from the returned base fee and reward percentiles
+func (s *RPCFeeHistorySource) Fetch(ctx context.Context) (GasTiers, error) {
+	reqBody, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "eth_feeHistory",
+		"params":  []interface{}{"0x1", "latest", []int{10, 50, 90}},
+	})
+	if err != nil {
+		return GasTiers{}, fmt.Errorf("rpc: building request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(reqBody))
+	if err != nil {
+		return GasTiers{}, fmt.Errorf("rpc: building request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := gasSourceClient.Do(req)
+	if err != nil {
+		return GasTiers{}, fmt.Errorf("rpc: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var out rpcFeeHistoryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return GasTiers{}, fmt.Errorf("rpc: decoding response: %w", err)
+	}
+	return rpcFeeHistoryTiers(out)
+}
+
+// rpcFeeHistoryResponse is the decoded shape of an eth_feeHistory response
+type rpcFeeHistoryResponse struct {
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+	Result struct {
+		BaseFeePerGas []string   `json:"baseFeePerGas"`
+		Reward        [][]string `json:"reward"`
+	} `json:"result"`
+}
+
+// rpcFeeHistoryTiers converts a decoded eth_feeHistory response into GasTiers,
+// deriving safe/propose/fast from the latest base fee and its three reward
+// percentiles
+func rpcFeeHistoryTiers(out rpcFeeHistoryResponse) (GasTiers, error) {
+	if out.Error != nil {
+		return GasTiers{}, fmt.Errorf("rpc: %s", out.Error.Message)
+	}
+	if len(out.Result.BaseFeePerGas) == 0 || len(out.Result.Reward) == 0 {
+		return GasTiers{}, fmt.Errorf("rpc: empty fee history returned")
+	}
+
+	base, err := parseHexWei(out.Result.BaseFeePerGas[len(out.Result.BaseFeePerGas)-1])
+	if err != nil {
+		return GasTiers{}, fmt.Errorf("rpc: parsing base fee: %w", err)
+	}
+
+	latestReward := out.Result.Reward[len(out.Result.Reward)-1]
+	if len(latestReward) != 3 {
+		return GasTiers{}, fmt.Errorf("rpc: expected 3 reward percentiles, got %d", len(latestReward))
+	}
+
+	safeTip, err := parseHexWei(latestReward[0])
+	if err != nil {
+		return GasTiers{}, fmt.Errorf("rpc: parsing safe tip: %w", err)
+	}
+	proposeTip, err := parseHexWei(latestReward[1])
+	if err != nil {
+		return GasTiers{}, fmt.Errorf("rpc: parsing propose tip: %w", err)
+	}
+	fastTip, err := parseHexWei(latestReward[2])
+	if err != nil {
+		return GasTiers{}, fmt.Errorf("rpc: parsing fast tip: %w", err)
+	}
+
+	return GasTiers{
+		BaseFeeGwei:           base,
+		SafeGwei:              base + safeTip,
+		ProposeGwei:           base + proposeTip,
+		FastGwei:              base + fastTip,
+		PriorityFeePercentile: fastTip,
+	}, nil
+}
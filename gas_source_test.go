@@ -0,0 +1,147 @@
+package main
+
+import "testing"
+
+func TestEtherscanTiers(t *testing.T) {
+	t.Run("error status", func(t *testing.T) {
+		out := etherscanResponse{Status: "0", Message: "rate limited"}
+		if _, err := etherscanTiers(out); err == nil {
+			t.Fatal("expected an error for a non-1 status")
+		}
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		out := etherscanResponse{Status: "1"}
+		out.Result.SafeGasPrice = "10"
+		out.Result.ProposeGasPrice = "15"
+		out.Result.FastGasPrice = "20"
+		out.Result.SuggestBaseFee = "8.5"
+
+		tiers, err := etherscanTiers(out)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if tiers.SafeGwei != 10 || tiers.ProposeGwei != 15 || tiers.FastGwei != 20 || tiers.BaseFeeGwei != 8.5 {
+			t.Fatalf("unexpected tiers: %+v", tiers)
+		}
+	})
+}
+
+func TestBlocknativeTiers(t *testing.T) {
+	t.Run("no block prices", func(t *testing.T) {
+		if _, err := blocknativeTiers(blocknativeResponse{}); err == nil {
+			t.Fatal("expected an error for empty blockPrices")
+		}
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		var out blocknativeResponse
+		out.BlockPrices = []struct {
+			BaseFeePerGas   float64 `json:"baseFeePerGas"`
+			EstimatedPrices []struct {
+				Confidence           int     `json:"confidence"`
+				Price                float64 `json:"price"`
+				MaxPriorityFeePerGas float64 `json:"maxPriorityFeePerGas"`
+			} `json:"estimatedPrices"`
+		}{{
+			BaseFeePerGas: 7,
+			EstimatedPrices: []struct {
+				Confidence           int     `json:"confidence"`
+				Price                float64 `json:"price"`
+				MaxPriorityFeePerGas float64 `json:"maxPriorityFeePerGas"`
+			}{
+				{Confidence: 70, Price: 10},
+				{Confidence: 90, Price: 15},
+				{Confidence: 99, Price: 20, MaxPriorityFeePerGas: 2},
+			},
+		}}
+
+		tiers, err := blocknativeTiers(out)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if tiers.BaseFeeGwei != 7 || tiers.SafeGwei != 10 || tiers.ProposeGwei != 15 || tiers.FastGwei != 20 || tiers.PriorityFeePercentile != 2 {
+			t.Fatalf("unexpected tiers: %+v", tiers)
+		}
+	})
+}
+
+func TestOwlracleTiers(t *testing.T) {
+	t.Run("no speeds", func(t *testing.T) {
+		if _, err := owlracleTiers(owlracleResponse{}); err == nil {
+			t.Fatal("expected an error for empty speeds")
+		}
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		var out owlracleResponse
+		out.BaseFee = 5
+		out.Speeds = []struct {
+			Acceptance           float64 `json:"acceptance"`
+			MaxFeePerGas         float64 `json:"maxFeePerGas"`
+			MaxPriorityFeePerGas float64 `json:"maxPriorityFeePerGas"`
+		}{
+			{Acceptance: 0.35, MaxFeePerGas: 10},
+			{Acceptance: 0.6, MaxFeePerGas: 15},
+			{Acceptance: 0.9, MaxFeePerGas: 20, MaxPriorityFeePerGas: 2},
+		}
+
+		tiers, err := owlracleTiers(out)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if tiers.BaseFeeGwei != 5 || tiers.SafeGwei != 10 || tiers.ProposeGwei != 15 || tiers.FastGwei != 20 || tiers.PriorityFeePercentile != 2 {
+			t.Fatalf("unexpected tiers: %+v", tiers)
+		}
+	})
+}
+
+func TestRPCFeeHistoryTiers(t *testing.T) {
+	t.Run("rpc error", func(t *testing.T) {
+		out := rpcFeeHistoryResponse{}
+		out.Error = &struct {
+			Message string `json:"message"`
+		}{Message: "boom"}
+		if _, err := rpcFeeHistoryTiers(out); err == nil {
+			t.Fatal("expected an error when the rpc response carries one")
+		}
+	})
+
+	t.Run("empty fee history", func(t *testing.T) {
+		if _, err := rpcFeeHistoryTiers(rpcFeeHistoryResponse{}); err == nil {
+			t.Fatal("expected an error for empty baseFeePerGas/reward")
+		}
+	})
+
+	t.Run("malformed hex", func(t *testing.T) {
+		var out rpcFeeHistoryResponse
+		out.Result.BaseFeePerGas = []string{"0xzz"}
+		out.Result.Reward = [][]string{{"0x1", "0x2", "0x3"}}
+		if _, err := rpcFeeHistoryTiers(out); err == nil {
+			t.Fatal("expected an error for a malformed base fee")
+		}
+	})
+
+	t.Run("wrong reward percentile count", func(t *testing.T) {
+		var out rpcFeeHistoryResponse
+		out.Result.BaseFeePerGas = []string{"0x3b9aca00"}
+		out.Result.Reward = [][]string{{"0x1"}}
+		if _, err := rpcFeeHistoryTiers(out); err == nil {
+			t.Fatal("expected an error when reward doesn't have 3 percentiles")
+		}
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		var out rpcFeeHistoryResponse
+		out.Result.BaseFeePerGas = []string{"0x3b9aca00"} // 1 gwei
+		out.Result.Reward = [][]string{{"0x3b9aca00", "0x77359400", "0xb2d05e00"}} // 1, 2, 3 gwei
+
+		tiers, err := rpcFeeHistoryTiers(out)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if tiers.BaseFeeGwei != 1 || tiers.SafeGwei != 2 || tiers.ProposeGwei != 3 || tiers.FastGwei != 4 || tiers.PriorityFeePercentile != 3 {
+			t.Fatalf("unexpected tiers: %+v", tiers)
+		}
+	})
+}
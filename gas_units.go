@@ -0,0 +1,21 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+)
+
+// parseGwei parses a decimal gwei value as returned by REST gas oracles
+func parseGwei(value string) (float64, error) {
+	return strconv.ParseFloat(strings.TrimSpace(value), 64)
+}
+
+// parseHexWei parses a 0x-prefixed hex wei value, as returned by
+// eth_feeHistory, into gwei
+func parseHexWei(value string) (float64, error) {
+	wei, err := strconv.ParseUint(strings.TrimPrefix(value, "0x"), 16, 64)
+	if err != nil {
+		return 0, err
+	}
+	return float64(wei) / 1e9, nil
+}
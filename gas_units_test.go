@@ -0,0 +1,68 @@
+package main
+
+import "testing"
+
+func TestParseGwei(t *testing.T) {
+	cases := []struct {
+		name    string
+		value   string
+		want    float64
+		wantErr bool
+	}{
+		{name: "integer", value: "42", want: 42},
+		{name: "decimal with whitespace", value: " 12.5 ", want: 12.5},
+		{name: "empty", value: "", wantErr: true},
+		{name: "not a number", value: "abc", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseGwei(tc.value)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("parseGwei(%q) = %v, want error", tc.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseGwei(%q) returned unexpected error: %s", tc.value, err)
+			}
+			if got != tc.want {
+				t.Fatalf("parseGwei(%q) = %v, want %v", tc.value, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseHexWei(t *testing.T) {
+	cases := []struct {
+		name    string
+		value   string
+		want    float64
+		wantErr bool
+	}{
+		{name: "one gwei", value: "0x3b9aca00", want: 1},
+		{name: "zero", value: "0x0", want: 0},
+		{name: "missing prefix", value: "3b9aca00", want: 1},
+		{name: "malformed hex", value: "0xzz", wantErr: true},
+		{name: "empty", value: "", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseHexWei(tc.value)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("parseHexWei(%q) = %v, want error", tc.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseHexWei(%q) returned unexpected error: %s", tc.value, err)
+			}
+			if got != tc.want {
+				t.Fatalf("parseHexWei(%q) = %v, want %v", tc.value, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// main starts the gas manager's HTTP server and blocks until it's told to
+// shut down
+func main() {
+	m := &Manager{
+		WatchingGas: make(map[string]*Gas),
+	}
+
+	router := mux.NewRouter()
+	registerGasRoutes(router, m)
+
+	m.Server = &http.Server{
+		Addr:    ":8080",
+		Handler: router,
+	}
+
+	go func() {
+		if err := m.Server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Errorf("Gas HTTP server error: %s", err)
+		}
+	}()
+
+	waitForShutdown(m)
+}
+
+// waitForShutdown blocks until SIGINT or SIGTERM is received, then drains
+// the manager so in-flight HTTP handlers and per-gas goroutines aren't
+// killed mid-work
+func waitForShutdown(m *Manager) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	<-sigCh
+
+	logger.Info("Received shutdown signal, draining manager")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := m.Shutdown(ctx); err != nil {
+		logger.Errorf("Graceful shutdown failed: %s", err)
+	}
+}
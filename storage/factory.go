@@ -0,0 +1,13 @@
+package storage
+
+import _ "github.com/mattn/go-sqlite3"
+
+// NewGasStore opens the MySQL-backed store when mysqlDSN is set, falling
+// back to an embedded SQLite database at sqlitePath so self-hosted users
+// without a MySQL server still get persistence across restarts
+func NewGasStore(mysqlDSN, sqlitePath string) (GasStore, error) {
+	if mysqlDSN != "" {
+		return NewSQLGasStore("mysql", mysqlDSN)
+	}
+	return NewSQLGasStore("sqlite3", sqlitePath)
+}
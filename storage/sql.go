@@ -0,0 +1,280 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/database/mysql"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jmoiron/sqlx"
+)
+
+//go:embed migrations/mysql/*.sql migrations/sqlite3/*.sql
+var migrationFiles embed.FS
+
+// SQLGasStore is a GasStore backed by sqlx, usable against MySQL or SQLite
+type SQLGasStore struct {
+	db         *sqlx.DB
+	driverName string
+}
+
+// NewSQLGasStore opens driverName/dsn and runs any pending migrations before
+// returning, so the schema is always current on boot
+func NewSQLGasStore(driverName, dsn string) (*SQLGasStore, error) {
+	db, err := sqlx.Connect(driverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to %s: %w", driverName, err)
+	}
+
+	if err := migrateSchema(db.DB, driverName); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrating schema: %w", err)
+	}
+
+	return &SQLGasStore{db: db, driverName: driverName}, nil
+}
+
+func migrateSchema(db *sql.DB, driverName string) error {
+	// the two backends disagree on autoincrement syntax (MySQL's
+	// AUTO_INCREMENT vs. SQLite's INTEGER PRIMARY KEY rowid alias), so each
+	// gets its own migration set rather than one shared across both
+	var driver database.Driver
+	var err error
+	switch driverName {
+	case "mysql":
+		driver, err = mysql.WithInstance(db, &mysql.Config{})
+	case "sqlite3":
+		driver, err = sqlite3.WithInstance(db, &sqlite3.Config{})
+	default:
+		return fmt.Errorf("unsupported driver for migrations: %s", driverName)
+	}
+	if err != nil {
+		return err
+	}
+
+	source, err := iofs.New(migrationFiles, "migrations/"+driverName)
+	if err != nil {
+		return err
+	}
+
+	m, err := migrate.NewWithInstance("iofs", source, driverName, driver)
+	if err != nil {
+		return err
+	}
+
+	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+		return err
+	}
+	return nil
+}
+
+// Upsert inserts a gas record, or updates the existing row keyed on
+// (token, networks) if one already exists
+func (s *SQLGasStore) Upsert(ctx context.Context, rec GasRecord) (GasRecord, error) {
+	networks := strings.Join(rec.Networks, ",")
+
+	var existingID int
+	err := s.db.GetContext(ctx, &existingID, "SELECT id FROM gases WHERE token = ? AND networks = ? LIMIT 1", rec.Token, networks)
+	switch {
+	case err == sql.ErrNoRows:
+		res, err := s.db.ExecContext(ctx,
+			"INSERT INTO gases(token, nickname, networks, frequency, provider, api_key, display_mode) values(?,?,?,?,?,?,?)",
+			rec.Token, rec.Nickname, networks, rec.Frequency, rec.Provider, rec.ApiKey, rec.DisplayMode)
+		if err != nil {
+			return GasRecord{}, fmt.Errorf("inserting gas: %w", err)
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return GasRecord{}, fmt.Errorf("reading inserted gas id: %w", err)
+		}
+		rec.ID = int(id)
+	case err != nil:
+		return GasRecord{}, fmt.Errorf("looking up gas: %w", err)
+	default:
+		rec.ID = existingID
+		if _, err := s.db.ExecContext(ctx,
+			"UPDATE gases SET token = ?, nickname = ?, networks = ?, frequency = ?, provider = ?, api_key = ?, display_mode = ? WHERE id = ?",
+			rec.Token, rec.Nickname, networks, rec.Frequency, rec.Provider, rec.ApiKey, rec.DisplayMode, rec.ID); err != nil {
+			return GasRecord{}, fmt.Errorf("updating gas: %w", err)
+		}
+	}
+
+	persisted, err := s.replaceAlerts(ctx, rec.ID, rec.Alerts)
+	if err != nil {
+		return GasRecord{}, err
+	}
+	rec.Alerts = persisted
+
+	return rec, nil
+}
+
+// replaceAlerts reconciles a gas row's alerts against what's already
+// persisted: rows whose ID still appears are updated in place, rows with no
+// ID (or an ID that no longer exists) are inserted fresh, and persisted rows
+// absent from alerts are deleted. This keeps an alert's ID stable across
+// writes that touch its siblings - a wipe-and-reinsert would hand out a new
+// auto-increment ID to every alert on the gas each time, breaking any client
+// that cached an ID between a GET and a later DELETE.
+func (s *SQLGasStore) replaceAlerts(ctx context.Context, gasID int, alerts []GasAlert) ([]GasAlert, error) {
+	existing, err := s.alertsFor(ctx, gasID)
+	if err != nil {
+		return nil, err
+	}
+	existingByID := make(map[int]bool, len(existing))
+	for _, alert := range existing {
+		existingByID[alert.ID] = true
+	}
+
+	keep := make(map[int]bool, len(alerts))
+	persisted := make([]GasAlert, len(alerts))
+	for i, alert := range alerts {
+		if alert.ID != 0 && existingByID[alert.ID] {
+			if _, err := s.db.ExecContext(ctx,
+				"UPDATE gas_alerts SET tier = ?, comparator = ?, gwei = ?, cooldown_seconds = ?, webhook_url = ? WHERE id = ? AND gas_id = ?",
+				alert.Tier, alert.Comparator, alert.Gwei, alert.CooldownSeconds, alert.WebhookURL, alert.ID, gasID); err != nil {
+				return nil, fmt.Errorf("updating gas alert: %w", err)
+			}
+			keep[alert.ID] = true
+			persisted[i] = alert
+			continue
+		}
+
+		res, err := s.db.ExecContext(ctx,
+			"INSERT INTO gas_alerts(gas_id, tier, comparator, gwei, cooldown_seconds, webhook_url) values(?,?,?,?,?,?)",
+			gasID, alert.Tier, alert.Comparator, alert.Gwei, alert.CooldownSeconds, alert.WebhookURL)
+		if err != nil {
+			return nil, fmt.Errorf("storing gas alert: %w", err)
+		}
+
+		id, err := res.LastInsertId()
+		if err != nil {
+			return nil, fmt.Errorf("reading inserted gas alert id: %w", err)
+		}
+
+		alert.ID = int(id)
+		keep[alert.ID] = true
+		persisted[i] = alert
+	}
+
+	for _, alert := range existing {
+		if keep[alert.ID] {
+			continue
+		}
+		if _, err := s.db.ExecContext(ctx, "DELETE FROM gas_alerts WHERE id = ?", alert.ID); err != nil {
+			return nil, fmt.Errorf("deleting gas alert: %w", err)
+		}
+	}
+
+	return persisted, nil
+}
+
+// Get returns the record for a single token/network-set, if one exists
+func (s *SQLGasStore) Get(ctx context.Context, token string, networks []string) (GasRecord, error) {
+	rec, err := s.scanGas(ctx, "token = ? AND networks = ?", token, strings.Join(networks, ","))
+	if err != nil {
+		return GasRecord{}, err
+	}
+	if len(rec) == 0 {
+		return GasRecord{}, sql.ErrNoRows
+	}
+	return rec[0], nil
+}
+
+// List returns every watched gas record
+func (s *SQLGasStore) List(ctx context.Context) ([]GasRecord, error) {
+	return s.scanGas(ctx, "1 = 1")
+}
+
+func (s *SQLGasStore) scanGas(ctx context.Context, where string, args ...interface{}) ([]GasRecord, error) {
+	type row struct {
+		ID          int    `db:"id"`
+		Token       string `db:"token"`
+		Nickname    bool   `db:"nickname"`
+		Networks    string `db:"networks"`
+		Frequency   int    `db:"frequency"`
+		Provider    string `db:"provider"`
+		ApiKey      string `db:"api_key"`
+		DisplayMode string `db:"display_mode"`
+	}
+
+	var rows []row
+	if err := s.db.SelectContext(ctx, &rows, "SELECT id, token, nickname, networks, frequency, provider, api_key, display_mode FROM gases WHERE "+where, args...); err != nil {
+		return nil, fmt.Errorf("listing gases: %w", err)
+	}
+
+	recs := make([]GasRecord, 0, len(rows))
+	for _, r := range rows {
+		alerts, err := s.alertsFor(ctx, r.ID)
+		if err != nil {
+			return nil, err
+		}
+		recs = append(recs, GasRecord{
+			ID:          r.ID,
+			Token:       r.Token,
+			Nickname:    r.Nickname,
+			Networks:    strings.Split(r.Networks, ","),
+			Frequency:   r.Frequency,
+			Provider:    r.Provider,
+			ApiKey:      r.ApiKey,
+			DisplayMode: r.DisplayMode,
+			Alerts:      alerts,
+		})
+	}
+	return recs, nil
+}
+
+func (s *SQLGasStore) alertsFor(ctx context.Context, gasID int) ([]GasAlert, error) {
+	type row struct {
+		ID              int     `db:"id"`
+		Tier            string  `db:"tier"`
+		Comparator      string  `db:"comparator"`
+		Gwei            float64 `db:"gwei"`
+		CooldownSeconds int     `db:"cooldown_seconds"`
+		WebhookURL      string  `db:"webhook_url"`
+	}
+
+	var rows []row
+	if err := s.db.SelectContext(ctx, &rows, "SELECT id, tier, comparator, gwei, cooldown_seconds, webhook_url FROM gas_alerts WHERE gas_id = ? ORDER BY id", gasID); err != nil {
+		return nil, fmt.Errorf("listing gas alerts: %w", err)
+	}
+
+	alerts := make([]GasAlert, 0, len(rows))
+	for _, r := range rows {
+		alerts = append(alerts, GasAlert{
+			ID:              r.ID,
+			Tier:            r.Tier,
+			Comparator:      r.Comparator,
+			Gwei:            r.Gwei,
+			CooldownSeconds: r.CooldownSeconds,
+			WebhookURL:      r.WebhookURL,
+		})
+	}
+	return alerts, nil
+}
+
+// Delete removes a watched gas entry and its alerts
+func (s *SQLGasStore) Delete(ctx context.Context, token string, networks []string) error {
+	// alerts are removed explicitly: MySQL ignores inline REFERENCES clauses
+	// and SQLite only enforces them with foreign_keys on, so ON DELETE
+	// CASCADE cannot be relied on in either backend
+	joined := strings.Join(networks, ",")
+	if _, err := s.db.ExecContext(ctx, "DELETE FROM gas_alerts WHERE gas_id IN (SELECT id FROM gases WHERE token = ? AND networks = ?)", token, joined); err != nil {
+		return fmt.Errorf("deleting gas alerts: %w", err)
+	}
+	_, err := s.db.ExecContext(ctx, "DELETE FROM gases WHERE token = ? AND networks = ?", token, joined)
+	if err != nil {
+		return fmt.Errorf("deleting gas: %w", err)
+	}
+	return nil
+}
+
+// Close releases the underlying database connection
+func (s *SQLGasStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,41 @@
+// Package storage persists watched gas tickers and their alerts. It replaces
+// the hand-written prepare/exec/scan calls that used to live alongside the
+// HTTP handlers with a single repository interface, so every new field only
+// needs to be taught to one place.
+package storage
+
+import "context"
+
+// GasAlert is a persisted threshold alert attached to a GasRecord
+type GasAlert struct {
+	ID              int
+	Tier            string
+	Comparator      string
+	Gwei            float64
+	CooldownSeconds int
+	WebhookURL      string
+}
+
+// GasRecord is the persisted form of a watched gas ticker
+type GasRecord struct {
+	ID          int
+	Token       string
+	Nickname    bool
+	Networks    []string
+	Frequency   int
+	Provider    string
+	ApiKey      string
+	DisplayMode string
+	Alerts      []GasAlert
+}
+
+// GasStore persists watched gas tickers. Implementations must treat
+// (Token, Networks) as the natural key: Upsert updates an existing row with
+// a matching key instead of creating a duplicate.
+type GasStore interface {
+	Upsert(ctx context.Context, rec GasRecord) (GasRecord, error)
+	Get(ctx context.Context, token string, networks []string) (GasRecord, error)
+	List(ctx context.Context) ([]GasRecord, error)
+	Delete(ctx context.Context, token string, networks []string) error
+	Close() error
+}